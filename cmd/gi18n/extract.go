@@ -0,0 +1,318 @@
+// Copyright GoFrame Author(https://goframe.org). All Rights Reserved.
+//
+// This Source Code Form is subject to the terms of the MIT License.
+// If a copy of the MIT was not distributed with this file,
+// You can obtain one at https://github.com/gogf/gf.
+
+package main
+
+import (
+	"encoding/json"
+	"flag"
+	"fmt"
+	"go/ast"
+	"go/constant"
+	"go/token"
+	"go/types"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+
+	"golang.org/x/tools/go/packages"
+	"golang.org/x/tools/go/ssa"
+	"golang.org/x/tools/go/ssa/ssautil"
+)
+
+// gi18nPackagePath is the default import path of the package whose T/Tf/... functions and
+// *Manager methods are extracted, overridable via the -pkg flag for forks and vendored copies.
+const gi18nPackagePath = "github.com/gogf/gf/i18n/gi18n"
+
+// ExtractedEntry describes a single translation key found in the source code, ready to be
+// merged into the per-language translation files under Options.Path by "gi18n merge".
+type ExtractedEntry struct {
+	Key          string   `json:"key"`
+	Message      string   `json:"message"`
+	Description  string   `json:"description,omitempty"`
+	Placeholders []string `json:"placeholders,omitempty"`
+	SourceRefs   []string `json:"sourceRefs"`
+}
+
+// descriptionCommentPrefix marks a comment, on the same line as an extraction call or on the
+// line immediately above it, as that call's Description, e.g.:
+//
+//	// i18n: shown on the checkout confirmation page
+//	m.T("order_confirmed")
+const descriptionCommentPrefix = "i18n:"
+
+// defaultFuncs lists the Manager methods, and their free-function aliases, whose key argument
+// is treated as a translation key by default: the first string argument, or for Tfl and
+// TranslateFormatLang the second one, which follows the language.
+var defaultFuncs = []string{"T", "Tf", "Tfl", "Translate", "TranslateFormat", "TranslateFormatLang"}
+
+// placeholderPattern matches the default gi18n delimiter, {#Name}, used to discover the
+// placeholder variables referenced by an extracted message.
+var placeholderPattern = regexp.MustCompile(`\{#(\w+)\}`)
+
+func runExtract(args []string) error {
+	fset := flag.NewFlagSet("extract", flag.ExitOnError)
+	funcsFlag := fset.String("funcs", "", "comma-separated list of function/method names to extract calls to, overriding the default set")
+	outFlag := fset.String("out", "extracted.gi18n.json", "name of the extracted output file, written once per source directory")
+	pkgFlag := fset.String("pkg", gi18nPackagePath, "import path of the package whose functions/methods in -funcs are extracted; calls to identically-named functions in other packages are ignored")
+	if err := fset.Parse(args); err != nil {
+		return err
+	}
+
+	funcs := defaultFuncs
+	if *funcsFlag != "" {
+		funcs = splitCSV(*funcsFlag)
+	}
+	funcSet := make(map[string]bool, len(funcs))
+	for _, name := range funcs {
+		funcSet[name] = true
+	}
+
+	patterns := fset.Args()
+	if len(patterns) == 0 {
+		patterns = []string{"./..."}
+	}
+
+	cfg := &packages.Config{
+		Mode: packages.NeedName | packages.NeedFiles | packages.NeedCompiledGoFiles |
+			packages.NeedImports | packages.NeedDeps | packages.NeedTypes |
+			packages.NeedTypesInfo | packages.NeedSyntax,
+	}
+	pkgs, err := packages.Load(cfg, patterns...)
+	if err != nil {
+		return fmt.Errorf("loading packages: %w", err)
+	}
+	if packages.PrintErrors(pkgs) > 0 {
+		return fmt.Errorf("packages contained errors")
+	}
+
+	prog, ssaPkgs := ssautil.AllPackages(pkgs, ssa.InstantiateGenerics)
+	prog.Build()
+
+	entriesByDir := make(map[string][]ExtractedEntry)
+	for i, ssaPkg := range ssaPkgs {
+		if ssaPkg == nil {
+			continue
+		}
+		dir := packageDir(pkgs[i])
+		descriptions := buildDescriptionIndex(pkgs[i].Fset, pkgs[i].Syntax)
+		entries := extractPackage(ssaPkg, pkgs[i].Fset, funcSet, *pkgFlag, descriptions)
+		if len(entries) > 0 {
+			entriesByDir[dir] = append(entriesByDir[dir], entries...)
+		}
+	}
+
+	for dir, entries := range entriesByDir {
+		entries = dedupeAndSort(entries)
+		out := filepath.Join(dir, *outFlag)
+		if err := writeJSON(out, entries); err != nil {
+			return err
+		}
+		fmt.Printf("gi18n: extracted %d message(s) to %s\n", len(entries), out)
+	}
+	return nil
+}
+
+func packageDir(pkg *packages.Package) string {
+	if len(pkg.GoFiles) > 0 {
+		return filepath.Dir(pkg.GoFiles[0])
+	}
+	return "."
+}
+
+// extractPackage walks every function of <pkg>, including methods and closures, collecting
+// constant string key arguments passed to any of <funcs> declared in package <pkgPath>.
+func extractPackage(pkg *ssa.Package, fset *token.FileSet, funcs map[string]bool, pkgPath string, descriptions map[string]map[int]string) []ExtractedEntry {
+	var entries []ExtractedEntry
+	for fn := range ssautil.AllFunctions(pkg.Prog) {
+		if fn.Pkg != pkg {
+			continue
+		}
+		entries = append(entries, extractFunction(fn, fset, funcs, pkgPath, descriptions)...)
+	}
+	return entries
+}
+
+// extractFunction inspects every call instruction of <fn> for calls to <funcs> declared in
+// package <pkgPath>, resolving the key argument as a compile-time constant string.
+func extractFunction(fn *ssa.Function, fset *token.FileSet, funcs map[string]bool, pkgPath string, descriptions map[string]map[int]string) []ExtractedEntry {
+	var entries []ExtractedEntry
+	for _, block := range fn.Blocks {
+		for _, instr := range block.Instrs {
+			call, ok := instr.(ssa.CallInstruction)
+			if !ok {
+				continue
+			}
+			common := call.Common()
+			if common.IsInvoke() {
+				continue
+			}
+			callee := common.StaticCallee()
+			if callee == nil || !funcs[callee.Name()] || calleePackagePath(callee) != pkgPath {
+				continue
+			}
+			// The key is the first explicit argument, or the second for Tfl and
+			// TranslateFormatLang, whose first is the language; for a method call,
+			// common.Args[0] additionally carries the receiver.
+			argIndex := 0
+			if callee.Signature.Recv() != nil {
+				argIndex = 1
+			}
+			if name := callee.Name(); name == "Tfl" || name == "TranslateFormatLang" {
+				argIndex++
+			}
+			if argIndex >= len(common.Args) {
+				continue
+			}
+			msg, ok := constString(common.Args[argIndex])
+			if !ok {
+				continue
+			}
+			pos := fset.Position(common.Pos())
+			entries = append(entries, ExtractedEntry{
+				Key:          msg,
+				Message:      msg,
+				Description:  lookupDescription(descriptions, pos),
+				Placeholders: placeholders(msg),
+				SourceRefs:   []string{pos.String()},
+			})
+		}
+	}
+	return entries
+}
+
+// buildDescriptionIndex scans <files> for "// i18n: ..." comments, indexing each one's
+// description text by the file and line it appears on, so a call on that same line (a trailing
+// comment) or the line below it (a leading comment on the call's statement) can be annotated.
+func buildDescriptionIndex(fset *token.FileSet, files []*ast.File) map[string]map[int]string {
+	index := make(map[string]map[int]string)
+	for _, file := range files {
+		for _, group := range file.Comments {
+			for _, c := range group.List {
+				text := strings.TrimSpace(strings.TrimPrefix(c.Text, "//"))
+				if !strings.HasPrefix(text, descriptionCommentPrefix) {
+					continue
+				}
+				desc := strings.TrimSpace(strings.TrimPrefix(text, descriptionCommentPrefix))
+				pos := fset.Position(c.Pos())
+				if index[pos.Filename] == nil {
+					index[pos.Filename] = make(map[int]string)
+				}
+				index[pos.Filename][pos.Line] = desc
+			}
+		}
+	}
+	return index
+}
+
+// lookupDescription returns the description indexed for <pos>'s line (a trailing comment on the
+// call itself) or the line immediately above it (a leading comment on the call's statement), or
+// "" if neither was annotated.
+func lookupDescription(descriptions map[string]map[int]string, pos token.Position) string {
+	lines := descriptions[pos.Filename]
+	if lines == nil {
+		return ""
+	}
+	if desc, ok := lines[pos.Line]; ok {
+		return desc
+	}
+	if desc, ok := lines[pos.Line-1]; ok {
+		return desc
+	}
+	return ""
+}
+
+// calleePackagePath returns the import path declaring <fn>: for a method, the package declaring
+// its receiver type; for a package-level function, the package declaring it. It returns "" when
+// neither is known (a receiver that is not a named type, or a function with no declaring
+// object), so such functions never match a configured package path. This keeps extraction scoped to the configured package instead of
+// matching any identically-named function or method anywhere in the tree.
+func calleePackagePath(fn *ssa.Function) string {
+	if recv := fn.Signature.Recv(); recv != nil {
+		t := recv.Type()
+		if ptr, ok := t.(*types.Pointer); ok {
+			t = ptr.Elem()
+		}
+		if named, ok := t.(*types.Named); ok {
+			return named.Obj().Pkg().Path()
+		}
+		return ""
+	}
+	if obj := fn.Object(); obj != nil && obj.Pkg() != nil {
+		return obj.Pkg().Path()
+	}
+	return ""
+}
+
+// constString reports whether <v> is a compile-time constant string, returning its value.
+func constString(v ssa.Value) (string, bool) {
+	c, ok := v.(*ssa.Const)
+	if !ok || c.Value == nil || c.Value.Kind() != constant.String {
+		return "", false
+	}
+	return constant.StringVal(c.Value), true
+}
+
+// placeholders returns the distinct {#Name} placeholder variables referenced by <message>, in
+// order of first appearance.
+func placeholders(message string) []string {
+	var (
+		names []string
+		seen  = make(map[string]bool)
+	)
+	for _, m := range placeholderPattern.FindAllStringSubmatch(message, -1) {
+		if seen[m[1]] {
+			continue
+		}
+		seen[m[1]] = true
+		names = append(names, m[1])
+	}
+	return names
+}
+
+// dedupeAndSort merges entries sharing the same key, combining their source references, and
+// returns the result sorted by key for deterministic output.
+func dedupeAndSort(entries []ExtractedEntry) []ExtractedEntry {
+	byKey := make(map[string]*ExtractedEntry, len(entries))
+	order := make([]string, 0, len(entries))
+	for _, e := range entries {
+		if existing, ok := byKey[e.Key]; ok {
+			existing.SourceRefs = append(existing.SourceRefs, e.SourceRefs...)
+			continue
+		}
+		ec := e
+		byKey[e.Key] = &ec
+		order = append(order, e.Key)
+	}
+	sort.Strings(order)
+	result := make([]ExtractedEntry, 0, len(order))
+	for _, k := range order {
+		e := *byKey[k]
+		sort.Strings(e.SourceRefs)
+		result = append(result, e)
+	}
+	return result
+}
+
+func writeJSON(path string, v interface{}) error {
+	data, err := json.MarshalIndent(v, "", "  ")
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(path, append(data, '\n'), 0644)
+}
+
+func splitCSV(s string) []string {
+	var out []string
+	for _, part := range regexp.MustCompile(`\s*,\s*`).Split(s, -1) {
+		if part != "" {
+			out = append(out, part)
+		}
+	}
+	return out
+}
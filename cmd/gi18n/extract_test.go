@@ -0,0 +1,134 @@
+// Copyright GoFrame Author(https://goframe.org). All Rights Reserved.
+//
+// This Source Code Form is subject to the terms of the MIT License.
+// If a copy of the MIT was not distributed with this file,
+// You can obtain one at https://github.com/gogf/gf.
+
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"sort"
+	"testing"
+
+	"golang.org/x/tools/go/packages"
+	"golang.org/x/tools/go/ssa"
+	"golang.org/x/tools/go/ssa/ssautil"
+)
+
+// writeExtractFixture lays out a throwaway module under <dir> with:
+//   - extracttest/gi18n: a fake gi18n package with a Manager.T method
+//   - extracttest/other: an unrelated type with its own, identically named T method
+//   - extracttest/app: a caller using both, with a "// i18n:" description comment on one call
+func writeExtractFixture(t *testing.T, dir string) {
+	t.Helper()
+	files := map[string]string{
+		"go.mod": "module extracttest\n\ngo 1.18\n",
+		"gi18n/gi18n.go": `package gi18n
+
+type Manager struct{}
+
+func (m *Manager) T(key string) string { return key }
+`,
+		"other/other.go": `package other
+
+// Stringer is unrelated to gi18n.Manager but happens to share a method name with it.
+type Stringer struct{}
+
+func (s Stringer) T(key string) string { return key }
+
+func UseOther() string {
+	var s Stringer
+	return s.T("not_a_translation_key")
+}
+`,
+		"app/app.go": `package app
+
+import "extracttest/gi18n"
+
+func UseIt() string {
+	m := &gi18n.Manager{}
+	// i18n: shown to the user after a successful save
+	return m.T("saved_ok")
+}
+`,
+	}
+	for path, content := range files {
+		full := filepath.Join(dir, path)
+		if err := os.MkdirAll(filepath.Dir(full), 0755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(full, []byte(content), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+}
+
+// extractFixture loads every package under <dir> and runs extractPackage against each,
+// scoping matches to <pkgPath>, returning the aggregated entries.
+func extractFixture(t *testing.T, dir string, pkgPath string) []ExtractedEntry {
+	t.Helper()
+	cfg := &packages.Config{
+		Dir: dir,
+		Mode: packages.NeedName | packages.NeedFiles | packages.NeedCompiledGoFiles |
+			packages.NeedImports | packages.NeedDeps | packages.NeedTypes |
+			packages.NeedTypesInfo | packages.NeedSyntax,
+	}
+	pkgs, err := packages.Load(cfg, "./...")
+	if err != nil {
+		t.Fatalf("loading packages: %v", err)
+	}
+	if packages.PrintErrors(pkgs) > 0 {
+		t.Fatalf("fixture packages contained errors")
+	}
+
+	prog, ssaPkgs := ssautil.AllPackages(pkgs, ssa.InstantiateGenerics)
+	prog.Build()
+
+	funcSet := map[string]bool{"T": true}
+	var entries []ExtractedEntry
+	for i, ssaPkg := range ssaPkgs {
+		if ssaPkg == nil {
+			continue
+		}
+		descriptions := buildDescriptionIndex(pkgs[i].Fset, pkgs[i].Syntax)
+		entries = append(entries, extractPackage(ssaPkg, pkgs[i].Fset, funcSet, pkgPath, descriptions)...)
+	}
+	sort.Slice(entries, func(i, j int) bool { return entries[i].Key < entries[j].Key })
+	return entries
+}
+
+func Test_ExtractFunction_ScopesToConfiguredPackage(t *testing.T) {
+	dir := t.TempDir()
+	writeExtractFixture(t, dir)
+
+	entries := extractFixture(t, dir, "extracttest/gi18n")
+
+	// Only the call on *extracttest/gi18n.Manager is extracted; the unrelated
+	// extracttest/other.Stringer.T call, despite sharing the method name, is not.
+	if len(entries) != 1 {
+		t.Fatalf("want 1 entry, got %d: %+v", len(entries), entries)
+	}
+	if entries[0].Key != "saved_ok" {
+		t.Fatalf("want key %q, got %q", "saved_ok", entries[0].Key)
+	}
+	if entries[0].Description != "shown to the user after a successful save" {
+		t.Fatalf("want description %q, got %q", "shown to the user after a successful save", entries[0].Description)
+	}
+}
+
+func Test_CalleePackagePath_ScopesByReceiverDeclaringPackage(t *testing.T) {
+	dir := t.TempDir()
+	writeExtractFixture(t, dir)
+
+	// Pointing -pkg at extracttest/other extracts only Stringer.T's call, since
+	// calleePackagePath resolves by the receiver's declaring package, not the method name.
+	entries := extractFixture(t, dir, "extracttest/other")
+	if len(entries) != 1 {
+		t.Fatalf("want 1 entry, got %d: %+v", len(entries), entries)
+	}
+	if entries[0].Key != "not_a_translation_key" {
+		t.Fatalf("want key %q, got %q", "not_a_translation_key", entries[0].Key)
+	}
+}
@@ -0,0 +1,62 @@
+// Copyright GoFrame Author(https://goframe.org). All Rights Reserved.
+//
+// This Source Code Form is subject to the terms of the MIT License.
+// If a copy of the MIT was not distributed with this file,
+// You can obtain one at https://github.com/gogf/gf.
+
+// Command gi18n extracts translation keys out of Go source code and merges them into the
+// per-language translation files consumed by gi18n.Manager, turning i18n key management into
+// a build step instead of a manual chore.
+package main
+
+import (
+	"flag"
+	"fmt"
+	"os"
+)
+
+func main() {
+	flag.Usage = usage
+	flag.Parse()
+
+	args := flag.Args()
+	if len(args) == 0 {
+		usage()
+		os.Exit(2)
+	}
+
+	var err error
+	switch args[0] {
+	case "extract":
+		err = runExtract(args[1:])
+	case "merge":
+		err = runMerge(args[1:])
+	default:
+		usage()
+		os.Exit(2)
+	}
+	if err != nil {
+		fmt.Fprintln(os.Stderr, "gi18n:", err)
+		os.Exit(1)
+	}
+}
+
+func usage() {
+	fmt.Fprintln(os.Stderr, `gi18n extracts and merges i18n translation keys out of Go source code.
+
+Usage:
+
+	gi18n extract [-funcs name,...] [-pkg import/path] [-out file] [packages]
+	gi18n merge [-path dir] -lang en,zh [-in file] [dirs]
+
+extract statically analyzes the given packages (default "./...") for calls to the configured
+translation functions/methods (default: T, Tf, Tfl, Translate, TranslateFormat,
+TranslateFormatLang) declared in -pkg (default "github.com/gogf/gf/i18n/gi18n"), ignoring
+identically-named functions/methods from other packages, and writes one "extracted.gi18n.json"
+file per source directory. A "// i18n: ..." comment on the call's line or the line above it is
+recorded as the entry's description.
+
+merge reads the "extracted.gi18n.json" files found under the given directories (default ".")
+and fans them out into the per-language translation files under -path, adding messages for new
+keys and reporting (without deleting) keys no longer referenced by the source code.`)
+}
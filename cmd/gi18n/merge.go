@@ -0,0 +1,146 @@
+// Copyright GoFrame Author(https://goframe.org). All Rights Reserved.
+//
+// This Source Code Form is subject to the terms of the MIT License.
+// If a copy of the MIT was not distributed with this file,
+// You can obtain one at https://github.com/gogf/gf.
+
+package main
+
+import (
+	"encoding/json"
+	"flag"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+)
+
+func runMerge(args []string) error {
+	fset := flag.NewFlagSet("merge", flag.ExitOnError)
+	pathFlag := fset.String("path", "i18n", "translation files directory to merge extracted messages into")
+	langFlag := fset.String("lang", "", "comma-separated list of languages to merge into, e.g. en,zh (required)")
+	inFlag := fset.String("in", "extracted.gi18n.json", "name of the extracted input file to look for under each given directory")
+	if err := fset.Parse(args); err != nil {
+		return err
+	}
+	if *langFlag == "" {
+		return fmt.Errorf("-lang is required, e.g. -lang en,zh")
+	}
+	langs := splitCSV(*langFlag)
+
+	roots := fset.Args()
+	if len(roots) == 0 {
+		roots = []string{"."}
+	}
+
+	extracted, err := collectExtracted(roots, *inFlag)
+	if err != nil {
+		return err
+	}
+	wanted := make(map[string]ExtractedEntry, len(extracted))
+	for _, e := range extracted {
+		wanted[e.Key] = e
+	}
+
+	for _, lang := range langs {
+		if err := mergeLanguage(*pathFlag, lang, wanted); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// collectExtracted walks <roots> for files named <name>, as produced by "gi18n extract", and
+// returns the union of their entries, deduplicated by key.
+func collectExtracted(roots []string, name string) ([]ExtractedEntry, error) {
+	byKey := make(map[string]*ExtractedEntry)
+	var order []string
+	for _, root := range roots {
+		err := filepath.WalkDir(root, func(path string, d fs.DirEntry, err error) error {
+			if err != nil {
+				return err
+			}
+			if d.IsDir() || d.Name() != name {
+				return nil
+			}
+			data, err := os.ReadFile(path)
+			if err != nil {
+				return err
+			}
+			var entries []ExtractedEntry
+			if err := json.Unmarshal(data, &entries); err != nil {
+				return fmt.Errorf("parsing %s: %w", path, err)
+			}
+			for _, e := range entries {
+				if existing, ok := byKey[e.Key]; ok {
+					existing.SourceRefs = append(existing.SourceRefs, e.SourceRefs...)
+					continue
+				}
+				ec := e
+				byKey[e.Key] = &ec
+				order = append(order, e.Key)
+			}
+			return nil
+		})
+		if err != nil {
+			return nil, err
+		}
+	}
+	result := make([]ExtractedEntry, 0, len(order))
+	for _, k := range order {
+		result = append(result, *byKey[k])
+	}
+	return result, nil
+}
+
+// mergeLanguage merges <wanted> into the translation file for <lang> under <path>: messages
+// are added for keys new to the file, existing translations are left untouched, and keys no
+// longer referenced by the extracted source are reported as obsolete rather than deleted, so a
+// reviewer can decide whether to remove them.
+func mergeLanguage(path string, lang string, wanted map[string]ExtractedEntry) error {
+	file := filepath.Join(path, lang+".json")
+	data := make(map[string]interface{})
+	if raw, err := os.ReadFile(file); err == nil {
+		if err := json.Unmarshal(raw, &data); err != nil {
+			return fmt.Errorf("parsing %s: %w", file, err)
+		}
+	} else if !os.IsNotExist(err) {
+		return err
+	}
+
+	var added, obsolete []string
+	for key, entry := range wanted {
+		if _, ok := data[key]; !ok {
+			data[key] = entry.Message
+			added = append(added, key)
+		}
+	}
+	for key := range data {
+		if _, ok := wanted[key]; !ok {
+			obsolete = append(obsolete, key)
+		}
+	}
+	sort.Strings(added)
+	sort.Strings(obsolete)
+
+	if len(added) > 0 {
+		if err := os.MkdirAll(path, 0755); err != nil {
+			return err
+		}
+		out, err := json.MarshalIndent(data, "", "  ")
+		if err != nil {
+			return err
+		}
+		if err := os.WriteFile(file, append(out, '\n'), 0644); err != nil {
+			return err
+		}
+	}
+
+	fmt.Printf("gi18n: %s: %d new, %d obsolete\n", file, len(added), len(obsolete))
+	if len(obsolete) > 0 {
+		fmt.Printf("gi18n: %s: obsolete keys (not referenced by extracted source, left untouched): %s\n", file, strings.Join(obsolete, ", "))
+	}
+	return nil
+}
@@ -0,0 +1,116 @@
+// Copyright GoFrame Author(https://goframe.org). All Rights Reserved.
+//
+// This Source Code Form is subject to the terms of the MIT License.
+// If a copy of the MIT was not distributed with this file,
+// You can obtain one at https://github.com/gogf/gf.
+
+package main
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func Test_MergeLanguage_AddsNewKeysAndReportsObsolete(t *testing.T) {
+	dir := t.TempDir()
+	existing := map[string]interface{}{
+		"hello": "Hello",
+		"bye":   "Bye",
+	}
+	writeJSONFixture(t, filepath.Join(dir, "en.json"), existing)
+
+	wanted := map[string]ExtractedEntry{
+		"hello": {Key: "hello", Message: "Hello"},
+		"saved": {Key: "saved", Message: "Saved"},
+	}
+	if err := mergeLanguage(dir, "en", wanted); err != nil {
+		t.Fatalf("mergeLanguage: %v", err)
+	}
+
+	got := readJSONFixture(t, filepath.Join(dir, "en.json"))
+	// "saved" is added using its extracted message, "hello" is left untouched even though it's
+	// also wanted, and "bye" is left in the file untouched since obsolete keys are only reported.
+	if got["hello"] != "Hello" {
+		t.Fatalf("want hello untouched, got %v", got["hello"])
+	}
+	if got["bye"] != "Bye" {
+		t.Fatalf("want obsolete key bye left in file, got %v", got["bye"])
+	}
+	if got["saved"] != "Saved" {
+		t.Fatalf("want new key saved added, got %v", got["saved"])
+	}
+}
+
+func Test_MergeLanguage_CreatesMissingFile(t *testing.T) {
+	dir := t.TempDir()
+	wanted := map[string]ExtractedEntry{
+		"hello": {Key: "hello", Message: "Hello"},
+	}
+	if err := mergeLanguage(dir, "en", wanted); err != nil {
+		t.Fatalf("mergeLanguage: %v", err)
+	}
+
+	got := readJSONFixture(t, filepath.Join(dir, "en.json"))
+	if got["hello"] != "Hello" {
+		t.Fatalf("want hello created, got %v", got["hello"])
+	}
+}
+
+func Test_MergeLanguage_NoNewKeysLeavesFileUnwritten(t *testing.T) {
+	dir := t.TempDir()
+	existing := map[string]interface{}{"hello": "Hello, translated"}
+	path := filepath.Join(dir, "en.json")
+	writeJSONFixture(t, path, existing)
+	before, err := os.Stat(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	wanted := map[string]ExtractedEntry{
+		"hello": {Key: "hello", Message: "Hello"},
+	}
+	if err := mergeLanguage(dir, "en", wanted); err != nil {
+		t.Fatalf("mergeLanguage: %v", err)
+	}
+
+	after, err := os.Stat(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !after.ModTime().Equal(before.ModTime()) {
+		t.Fatalf("want file left untouched when there are no new keys")
+	}
+	got := readJSONFixture(t, path)
+	if got["hello"] != "Hello, translated" {
+		t.Fatalf("want existing translation preserved, got %v", got["hello"])
+	}
+}
+
+func writeJSONFixture(t *testing.T, path string, v interface{}) {
+	t.Helper()
+	data, err := json.Marshal(v)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(path, data, 0644); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func readJSONFixture(t *testing.T, path string) map[string]interface{} {
+	t.Helper()
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var v map[string]interface{}
+	if err := json.Unmarshal(data, &v); err != nil {
+		t.Fatal(err)
+	}
+	return v
+}
@@ -0,0 +1,50 @@
+// Copyright GoFrame Author(https://goframe.org). All Rights Reserved.
+//
+// This Source Code Form is subject to the terms of the MIT License.
+// If a copy of the MIT was not distributed with this file,
+// You can obtain one at https://github.com/gogf/gf.
+
+package gi18n
+
+import (
+	"context"
+
+	"golang.org/x/text/language"
+)
+
+// ctxKey is the context key type under which the request language is stored, to avoid
+// collisions with keys set by other packages.
+type ctxKey string
+
+// ctxLanguageKey is the context key storing the language set by WithLanguage/WithAcceptLanguage.
+const ctxLanguageKey ctxKey = "gi18n.Language"
+
+// WithLanguage appends <language> to <ctx> and returns the derived context. The returned
+// context can be passed down the call chain and later read back with LanguageFromCtx, e.g. to
+// pass it as the <language> argument of Manager.Translate.
+func WithLanguage(ctx context.Context, language string) context.Context {
+	return context.WithValue(ctx, ctxLanguageKey, language)
+}
+
+// WithAcceptLanguage parses the HTTP "Accept-Language" header value <acceptLanguage> and
+// stores its highest-priority language tag into <ctx>, for later resolution by
+// Manager.Translate or Manager.MatchLanguage against the languages it actually supports.
+func WithAcceptLanguage(ctx context.Context, acceptLanguage string) context.Context {
+	tags, _, err := language.ParseAcceptLanguage(acceptLanguage)
+	if err != nil || len(tags) == 0 {
+		return ctx
+	}
+	return WithLanguage(ctx, tags[0].String())
+}
+
+// LanguageFromCtx retrieves the language previously set into <ctx> by WithLanguage or
+// WithAcceptLanguage. It returns an empty string if none was set.
+func LanguageFromCtx(ctx context.Context) string {
+	if ctx == nil {
+		return ""
+	}
+	if v, ok := ctx.Value(ctxLanguageKey).(string); ok {
+		return v
+	}
+	return ""
+}
@@ -0,0 +1,54 @@
+// Copyright GoFrame Author(https://goframe.org). All Rights Reserved.
+//
+// This Source Code Form is subject to the terms of the MIT License.
+// If a copy of the MIT was not distributed with this file,
+// You can obtain one at https://github.com/gogf/gf.
+
+package gi18n
+
+import (
+	"context"
+	"testing"
+
+	"github.com/gogf/gf/test/gtest"
+)
+
+func Test_WithLanguage(t *testing.T) {
+	gtest.C(t, func(t *gtest.T) {
+		ctx := WithLanguage(context.Background(), "fr-CA")
+		t.Assert(LanguageFromCtx(ctx), "fr-CA")
+	})
+}
+
+func Test_WithAcceptLanguage(t *testing.T) {
+	gtest.C(t, func(t *gtest.T) {
+		// The highest-priority tag of the Accept-Language header is stored.
+		ctx := WithAcceptLanguage(context.Background(), "fr-CH, fr;q=0.9, en;q=0.8, de;q=0.7")
+		t.Assert(LanguageFromCtx(ctx), "fr-CH")
+
+		// An empty header leaves the context untouched.
+		ctx = WithAcceptLanguage(context.Background(), "")
+		t.Assert(LanguageFromCtx(ctx), "")
+	})
+}
+
+func Test_LanguageFromCtx_Empty(t *testing.T) {
+	gtest.C(t, func(t *gtest.T) {
+		t.Assert(LanguageFromCtx(context.Background()), "")
+		t.Assert(LanguageFromCtx(nil), "")
+	})
+}
+
+func Test_Manager_Translate_WithCtxLanguage(t *testing.T) {
+	gtest.C(t, func(t *gtest.T) {
+		m := new()
+		m.data = map[string]map[string]interface{}{
+			"en": {"hello": "Hello"},
+			"fr": {"hello": "Bonjour"},
+		}
+		m.buildMatcher()
+
+		ctx := WithAcceptLanguage(context.Background(), "fr")
+		t.Assert(m.Translate("hello", LanguageFromCtx(ctx)), "Bonjour")
+	})
+}
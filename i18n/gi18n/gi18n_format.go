@@ -0,0 +1,119 @@
+// Copyright GoFrame Author(https://goframe.org). All Rights Reserved.
+//
+// This Source Code Form is subject to the terms of the MIT License.
+// If a copy of the MIT was not distributed with this file,
+// You can obtain one at https://github.com/gogf/gf.
+
+package gi18n
+
+import (
+	"encoding/json"
+	"sync"
+
+	"github.com/BurntSushi/toml"
+	"gopkg.in/yaml.v3"
+
+	"github.com/gogf/gf/internal/intlog"
+	"github.com/gogf/gf/os/gfile"
+)
+
+// UnmarshalFunc decodes raw message file content into <v>, in the same signature as
+// json.Unmarshal, yaml.Unmarshal and toml.Unmarshal.
+type UnmarshalFunc func(data []byte, v interface{}) error
+
+var (
+	defaultUnmarshalFuncsMu sync.RWMutex
+	// defaultUnmarshalFuncs is the package-wide registry of message format loaders, keyed by
+	// file extension (including the leading dot). It is consulted by every Manager that has
+	// not registered its own override for a given extension.
+	defaultUnmarshalFuncs = map[string]UnmarshalFunc{
+		".json": json.Unmarshal,
+		".yaml": yaml.Unmarshal,
+		".yml":  yaml.Unmarshal,
+		".toml": toml.Unmarshal,
+	}
+)
+
+// RegisterUnmarshalFunc registers <fn> as the message format loader for files with extension
+// <ext> (e.g. ".yaml") for all Manager instances that do not override it themselves via
+// Manager.RegisterUnmarshalFunc.
+func RegisterUnmarshalFunc(ext string, fn UnmarshalFunc) {
+	defaultUnmarshalFuncsMu.Lock()
+	defer defaultUnmarshalFuncsMu.Unlock()
+	defaultUnmarshalFuncs[ext] = fn
+}
+
+// RegisterUnmarshalFunc registers <fn> as the message format loader for files with extension
+// <ext> (e.g. ".yaml"), for this Manager only. This allows a Manager to read translation files
+// in formats other than the built-in JSON/YAML/TOML, such as the gotext.json format produced
+// by golang.org/x/text/message.
+func (m *Manager) RegisterUnmarshalFunc(ext string, fn UnmarshalFunc) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if m.unmarshalFuncs == nil {
+		m.unmarshalFuncs = make(map[string]UnmarshalFunc)
+	}
+	m.unmarshalFuncs[ext] = fn
+}
+
+// unmarshalFuncFor returns the unmarshal function to use for <name>, along with the extension
+// it was resolved from. Options.MessageFormat, if set, overrides the extension derived from
+// <name> for formats that cannot be trusted to carry a meaningful one, e.g. an embed.FS entry
+// named "en.txt". A Manager-level registration takes priority over the package-level registry.
+func (m *Manager) unmarshalFuncFor(name string) (UnmarshalFunc, string) {
+	ext := gfile.Ext(name)
+	if m.options.MessageFormat != "" {
+		ext = m.options.MessageFormat
+	}
+	if fn, ok := m.unmarshalFuncs[ext]; ok {
+		return fn, ext
+	}
+	defaultUnmarshalFuncsMu.RLock()
+	defer defaultUnmarshalFuncsMu.RUnlock()
+	if fn, ok := defaultUnmarshalFuncs[ext]; ok {
+		return fn, ext
+	}
+	return nil, ext
+}
+
+// loadMessages decodes <content>, read from the file named <name>, into the translation map
+// for <lang> under namespace <ns> ("" for the default namespace), dispatching to the unmarshal
+// function registered for the file's message format. It must be called with m.mu held for
+// writing.
+//
+// Keys that <name> contributed on a previous call (tracked via m.fileKeys) but no longer decodes
+// to are removed from m.data[lang], so that a hot reload of a changed file drops keys removed
+// from it instead of leaving them stale forever.
+func (m *Manager) loadMessages(ns string, lang string, name string, content []byte) {
+	fn, ext := m.unmarshalFuncFor(name)
+	if fn == nil {
+		intlog.Errorf("load i18n file '%s' failed: no unmarshal function registered for format '%s'", name, ext)
+		return
+	}
+	var data map[string]interface{}
+	if err := fn(content, &data); err != nil {
+		intlog.Errorf("load i18n file '%s' failed: %v", name, err)
+		return
+	}
+	if m.data[lang] == nil {
+		m.data[lang] = make(map[string]interface{})
+	}
+	newKeys := make(map[string]bool, len(data))
+	for k, v := range data {
+		composite := nsKey(ns, k)
+		m.data[lang][composite] = v
+		newKeys[composite] = true
+	}
+	if prev, ok := m.fileKeys[name]; ok && prev.lang == lang {
+		for k := range prev.keys {
+			if !newKeys[k] {
+				delete(m.data[lang], k)
+			}
+		}
+	}
+	if m.fileKeys == nil {
+		m.fileKeys = make(map[string]fileKeySet)
+	}
+	m.fileKeys[name] = fileKeySet{lang: lang, keys: newKeys}
+	intlog.Printf("load i18n file '%s' success, lang: '%s', namespace: '%s'", name, lang, ns)
+}
@@ -0,0 +1,101 @@
+// Copyright GoFrame Author(https://goframe.org). All Rights Reserved.
+//
+// This Source Code Form is subject to the terms of the MIT License.
+// If a copy of the MIT was not distributed with this file,
+// You can obtain one at https://github.com/gogf/gf.
+
+package gi18n
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/gogf/gf/test/gtest"
+)
+
+func Test_Manager_LoadMessages_Yaml(t *testing.T) {
+	gtest.C(t, func(t *gtest.T) {
+		m := new()
+		m.data = make(map[string]map[string]interface{})
+		m.loadMessages("", "en", "en.yaml", []byte("hello: Hello\n"))
+		t.Assert(m.data["en"]["hello"], "Hello")
+	})
+}
+
+func Test_Manager_LoadMessages_Toml(t *testing.T) {
+	gtest.C(t, func(t *gtest.T) {
+		m := new()
+		m.data = make(map[string]map[string]interface{})
+		m.loadMessages("", "en", "en.toml", []byte(`hello = "Hello"`))
+		t.Assert(m.data["en"]["hello"], "Hello")
+	})
+}
+
+func Test_Manager_RegisterUnmarshalFunc(t *testing.T) {
+	gtest.C(t, func(t *gtest.T) {
+		// A Manager-level registration overrides the package-level registry for this Manager
+		// only, without affecting other Managers using the same extension.
+		m := new()
+		m.data = make(map[string]map[string]interface{})
+		m.RegisterUnmarshalFunc(".ini", func(data []byte, v interface{}) error {
+			out := v.(*map[string]interface{})
+			*out = map[string]interface{}{"hello": "Hello from ini"}
+			return nil
+		})
+		m.loadMessages("", "en", "en.ini", []byte("unused"))
+		t.Assert(m.data["en"]["hello"], "Hello from ini")
+
+		other := new()
+		other.data = make(map[string]map[string]interface{})
+		other.loadMessages("", "en", "en.ini", []byte("unused"))
+		t.Assert(len(other.data["en"]), 0)
+	})
+}
+
+func Test_RegisterUnmarshalFunc_PackageLevel(t *testing.T) {
+	gtest.C(t, func(t *gtest.T) {
+		RegisterUnmarshalFunc(".properties", func(data []byte, v interface{}) error {
+			out := v.(*map[string]interface{})
+			*out = map[string]interface{}{"hello": "Hello from properties"}
+			return nil
+		})
+		defer delete(defaultUnmarshalFuncs, ".properties")
+
+		m := new()
+		m.data = make(map[string]map[string]interface{})
+		m.loadMessages("", "en", "en.properties", []byte("unused"))
+		t.Assert(m.data["en"]["hello"], "Hello from properties")
+	})
+}
+
+func Test_Manager_LoadMessages_MessageFormatOverride(t *testing.T) {
+	gtest.C(t, func(t *gtest.T) {
+		// A ".txt" extension carries no meaningful format on its own; Options.MessageFormat
+		// forces it to be decoded as JSON regardless.
+		m := new(Options{MessageFormat: ".json"})
+		m.data = make(map[string]map[string]interface{})
+		m.loadMessages("", "en", "en.txt", []byte(`{"hello":"Hello"}`))
+		t.Assert(m.data["en"]["hello"], "Hello")
+	})
+}
+
+func Test_Manager_LoadMessages_UnknownFormat(t *testing.T) {
+	gtest.C(t, func(t *gtest.T) {
+		m := new()
+		m.data = make(map[string]map[string]interface{})
+		m.loadMessages("", "en", "en.unknown", []byte("irrelevant"))
+		t.Assert(len(m.data["en"]), 0)
+	})
+}
+
+func Test_Manager_LoadMessages_DecodeError(t *testing.T) {
+	gtest.C(t, func(t *gtest.T) {
+		m := new()
+		m.data = make(map[string]map[string]interface{})
+		m.RegisterUnmarshalFunc(".bad", func(data []byte, v interface{}) error {
+			return errors.New("boom")
+		})
+		m.loadMessages("", "en", "en.bad", []byte("irrelevant"))
+		t.Assert(len(m.data["en"]), 0)
+	})
+}
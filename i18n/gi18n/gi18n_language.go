@@ -0,0 +1,128 @@
+// Copyright GoFrame Author(https://goframe.org). All Rights Reserved.
+//
+// This Source Code Form is subject to the terms of the MIT License.
+// If a copy of the MIT was not distributed with this file,
+// You can obtain one at https://github.com/gogf/gf.
+
+package gi18n
+
+import (
+	"sort"
+
+	"github.com/gogf/gf/internal/intlog"
+	"golang.org/x/text/language"
+)
+
+// buildMatcher builds the BCP-47 language.Matcher used by MatchLanguage to resolve a
+// requested language (e.g. "zh-Hant-HK") against the languages actually discovered under
+// Options.Path, plus any explicit Options.FallbackLanguages. It must be called with m.mu held
+// for writing, after m.data has been populated.
+//
+// language.NewMatcher treats its first tag as the match returned when nothing else qualifies,
+// so Options.Language is always registered first; the remaining languages are registered in
+// sorted order so the matcher is deterministic regardless of m.data's (randomized) map iteration
+// order.
+func (m *Manager) buildMatcher() {
+	var (
+		names = make([]string, 0, len(m.data)+len(m.options.FallbackLanguages)+1)
+		tags  = make([]language.Tag, 0, len(m.data)+len(m.options.FallbackLanguages)+1)
+		seen  = make(map[string]bool, len(m.data)+len(m.options.FallbackLanguages)+1)
+	)
+	add := func(name string) {
+		if seen[name] {
+			return
+		}
+		seen[name] = true
+		tag, err := language.Parse(name)
+		if err != nil {
+			intlog.Errorf("i18n parse language tag '%s' failed: %v", name, err)
+			return
+		}
+		names = append(names, name)
+		tags = append(tags, tag)
+	}
+	add(m.options.Language)
+	sortedData := make([]string, 0, len(m.data))
+	for lang := range m.data {
+		sortedData = append(sortedData, lang)
+	}
+	sort.Strings(sortedData)
+	for _, lang := range sortedData {
+		add(lang)
+	}
+	for _, lang := range m.options.FallbackLanguages {
+		add(lang)
+	}
+	if len(tags) == 0 {
+		m.matcher = nil
+		m.matcherNames = nil
+		return
+	}
+	m.matcherNames = names
+	m.matcher = language.NewMatcher(tags)
+}
+
+// MatchLanguage resolves the best supported language for the given BCP-47 <tags>, by matching
+// them against the languages discovered under Options.Path and Options.FallbackLanguages. It
+// returns the first parsable tag, in canonical BCP-47 form, if no matcher has been built yet, or the configured
+// default language (Options.Language) if none of <tags> can be parsed as a BCP-47 tag.
+func (m *Manager) MatchLanguage(tags ...string) string {
+	m.init()
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	return m.matchLanguage(tags...)
+}
+
+// matchLanguage is the lock-free implementation of MatchLanguage, for internal callers that
+// already hold m.mu.
+func (m *Manager) matchLanguage(tags ...string) string {
+	parsed := make([]language.Tag, 0, len(tags))
+	for _, t := range tags {
+		if t == "" {
+			continue
+		}
+		if tag, err := language.Parse(t); err == nil {
+			parsed = append(parsed, tag)
+		}
+	}
+	if len(parsed) == 0 {
+		return m.options.Language
+	}
+	if m.matcher == nil {
+		// Without a matcher there is nothing to negotiate against; returning the requested tag
+		// itself (rather than the configured default) keeps an explicitly requested language
+		// from being shadowed by the default in resolveLanguageChain.
+		return parsed[0].String()
+	}
+	_, index, confidence := m.matcher.Match(parsed...)
+	if confidence == language.No {
+		return m.options.Language
+	}
+	if index >= 0 && index < len(m.matcherNames) {
+		return m.matcherNames[index]
+	}
+	return m.options.Language
+}
+
+// resolveLanguageChain returns the ordered list of languages to search for a translation of
+// the requested <transLang>: the best BCP-47 match for <transLang> (see MatchLanguage), the
+// raw <transLang> itself, each of Options.FallbackLanguages in order, and finally the
+// configured default language. Duplicates are removed, keeping the first occurrence.
+func (m *Manager) resolveLanguageChain(transLang string) []string {
+	chain := make([]string, 0, len(m.options.FallbackLanguages)+3)
+	seen := make(map[string]bool, cap(chain))
+	add := func(lang string) {
+		if lang == "" || seen[lang] {
+			return
+		}
+		seen[lang] = true
+		chain = append(chain, lang)
+	}
+	add(m.matchLanguage(transLang))
+	add(transLang)
+	for _, lang := range m.options.FallbackLanguages {
+		add(lang)
+	}
+	add(m.options.Language)
+	return chain
+}
@@ -0,0 +1,74 @@
+// Copyright GoFrame Author(https://goframe.org). All Rights Reserved.
+//
+// This Source Code Form is subject to the terms of the MIT License.
+// If a copy of the MIT was not distributed with this file,
+// You can obtain one at https://github.com/gogf/gf.
+
+package gi18n
+
+import (
+	"testing"
+
+	"github.com/gogf/gf/test/gtest"
+)
+
+func Test_Manager_MatchLanguage(t *testing.T) {
+	gtest.C(t, func(t *gtest.T) {
+		m := new()
+		m.data = map[string]map[string]interface{}{
+			"en":    {"hello": "Hello"},
+			"zh":    {"hello": "你好"},
+			"zh-TW": {"hello": "你好(繁體)"},
+		}
+		m.buildMatcher()
+
+		t.Assert(m.MatchLanguage("zh-Hant-HK"), "zh-TW")
+		t.Assert(m.MatchLanguage("zh-CN"), "zh")
+		t.Assert(m.MatchLanguage("en-US"), "en")
+		// With no language given at all, the configured default is returned.
+		t.Assert(m.MatchLanguage(), m.options.Language)
+	})
+}
+
+func Test_Manager_MatchLanguage_UnmatchedFallsBackToDefault(t *testing.T) {
+	gtest.C(t, func(t *gtest.T) {
+		m := new()
+		m.options.Language = "en"
+		m.data = map[string]map[string]interface{}{
+			"en": {"hello": "Hello"},
+			"fr": {"hello": "Bonjour"},
+			"de": {"hello": "Hallo"},
+			"ja": {"hello": "Konnichiwa"},
+			"ko": {"hello": "Annyeong"},
+		}
+		// Run repeatedly: with a randomized map-iteration tag order feeding the matcher, a
+		// parseable but unsupported tag like "zh" could previously resolve to whichever
+		// language happened to be registered first, instead of reliably falling back to the
+		// configured default.
+		for i := 0; i < 20; i++ {
+			m.matcher = nil
+			m.matcherNames = nil
+			m.buildMatcher()
+			t.Assert(m.MatchLanguage("zh"), "en")
+			t.Assert(m.Translate("hello", "zh"), "Hello")
+		}
+	})
+}
+
+func Test_Manager_Translate_FallbackChain(t *testing.T) {
+	gtest.C(t, func(t *gtest.T) {
+		m := new()
+		m.options.Language = "en"
+		m.options.FallbackLanguages = []string{"en"}
+		m.data = map[string]map[string]interface{}{
+			"en": {"hello": "Hello"},
+			"fr": {"bonjour": "Bonjour"},
+		}
+		m.buildMatcher()
+
+		// "fr-CA" negotiates down to "fr", which has "bonjour" but not "hello"; the explicit
+		// fallback chain then reaches "en".
+		t.Assert(m.Translate("hello", "fr-CA"), "Hello")
+		t.Assert(m.Translate("bonjour", "fr-CA"), "Bonjour")
+	})
+}
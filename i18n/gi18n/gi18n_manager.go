@@ -9,19 +9,16 @@ package gi18n
 import (
 	"errors"
 	"fmt"
-	"io/fs"
-	"strings"
 	"sync"
+	"time"
 
 	"github.com/gogf/gf/internal/intlog"
 
-	"github.com/gogf/gf/os/gfsnotify"
-
 	"github.com/gogf/gf/text/gregex"
 
 	"github.com/gogf/gf/util/gconv"
 
-	"github.com/gogf/gf/encoding/gjson"
+	"golang.org/x/text/language"
 
 	"embed"
 
@@ -31,18 +28,40 @@ import (
 
 // Manager, it is concurrent safe, supporting hot reload.
 type Manager struct {
-	mu      sync.RWMutex
-	data    map[string]map[string]string // Translating map.
-	pattern string                       // Pattern for regex parsing.
-	options Options                      // configuration options.
+	mu             sync.RWMutex
+	data           map[string]map[string]interface{} // Translating map. Value is either a plain string or a structured plural/select message. Keys are prefixed with "namespace:" for non-default namespaces.
+	pattern        string                            // Pattern for regex parsing.
+	options        Options                           // configuration options.
+	unmarshalFuncs map[string]UnmarshalFunc          // Manager-level message format loaders, keyed by file extension.
+	matcher        language.Matcher                  // BCP-47 matcher built from the discovered and fallback languages.
+	matcherNames   []string                          // Language names passed to language.NewMatcher, in the same order as its tags.
+	sources        []source                          // Additional sources registered via RegisterSource, merged in on top of the Options-derived ones.
+	reloadFuncs    []func(lang string)               // Callbacks registered via OnReload.
+	watchMu        sync.Mutex                        // Guards pendingReloads/reloadTimer, independent of mu so scheduling a reload never blocks a concurrent Translate.
+	pendingReloads map[string]reloadTask             // Changed file path => pending reload task, coalesced by reloadTimer.
+	reloadTimer    *time.Timer                       // Debounce timer; fires flushReloads once events stop arriving for hotReloadDebounce.
+	fileKeys       map[string]fileKeySet             // File path => the composite keys it last contributed to m.data, so a reload can drop keys removed from the file.
+}
+
+// fileKeySet records the language and composite data keys a single translation file last
+// contributed, so that reloading it can remove keys no longer present without disturbing keys
+// contributed by other files for the same language.
+type fileKeySet struct {
+	lang string
+	keys map[string]bool
 }
 
 // Options is used for i18n object configuration.
 type Options struct {
-	Path       string   // I18n files storage path.
-	Language   string   // Local language.
-	Delimiters []string // Delimiters for variable parsing.
-	FS         *embed.FS
+	Path              string   // I18n files storage path.
+	Language          string   // Local language.
+	Delimiters        []string // Delimiters for variable parsing.
+	FS                *embed.FS
+	MessageFormat     string   // Forces the message format (e.g. ".json") instead of deriving it from the file extension. Useful when the extension cannot be trusted, e.g. an embed.FS entry named "en.txt".
+	FallbackLanguages []string          // Explicit BCP-47 fallback chain consulted by language negotiation, in addition to the languages discovered under Path.
+	Namespaces        map[string]string // Additional namespace => directory sources, merged into the data under "namespace:key", e.g. {"billing": "billing/i18n"}.
+	LanguagePaths     map[string]string // Per-language content directory overrides for the default namespace, e.g. {"fr": "i18n-fr"}, inspired by Hugo's per-language contentDir.
+	DisableHotReload  bool              // Disables the filesystem watcher started for plain filesystem sources. Has no effect on embed.FS/gres sources, which are never watched. Recommended for production.
 }
 
 var (
@@ -145,6 +164,16 @@ func (m *Manager) Tfl(language string, format string, values ...interface{}) str
 	return m.TranslateFormatLang(language, format, values...)
 }
 
+// Tp is alias of TranslatePlural for convenience.
+func (m *Manager) Tp(key string, count interface{}, args map[string]interface{}) string {
+	return m.TranslatePlural(key, count, args)
+}
+
+// Tpl is alias of TranslatePlural for convenience, with an explicit language override.
+func (m *Manager) Tpl(language string, key string, count interface{}, args map[string]interface{}) string {
+	return m.TranslatePlural(key, count, args, language)
+}
+
 // TranslateFormat translates, formats and returns the <format> with configured language
 // and given <values>.
 func (m *Manager) TranslateFormat(format string, values ...interface{}) string {
@@ -171,24 +200,192 @@ func (m *Manager) Translate(content string, language ...string) string {
 	} else {
 		transLang = m.options.Language
 	}
-	data := m.data[transLang]
+	// Parse content as name, walking the negotiated language fallback chain before giving up.
+	for _, lang := range m.resolveLanguageChain(transLang) {
+		data := m.data[lang]
+		if data == nil {
+			continue
+		}
+		if v, ok := data[content]; ok {
+			intlog.Printf(`Translate for language: %s`, lang)
+			return gconv.String(v)
+		}
+	}
+	// Parse content as variables container, using the best-matched language's data, if any.
+	data := m.data[m.matchLanguage(transLang)]
+	result, _ := gregex.ReplaceStringFuncMatch(
+		m.pattern, content,
+		func(match []string) string {
+			if data != nil {
+				if v, ok := data[match[1]]; ok {
+					return gconv.String(v)
+				}
+			}
+			return match[0]
+		})
+	return result
+}
+
+// TranslateNS translates <key> within namespace <ns> (e.g. "common", "billing"), as registered
+// via Options.Namespaces or Manager.RegisterSource. It is equivalent to calling
+// Translate(ns + ":" + key, language...); the same "namespace:key" syntax can be used directly
+// with Translate.
+func (m *Manager) TranslateNS(ns string, key string, language ...string) string {
+	return m.Translate(nsKey(ns, key), language...)
+}
+
+// TranslatePlural translates <key> using the CLDR plural form resolved from <count> and the
+// configured language, substituting <args> (and the auto-populated "Count") into placeholders
+// such as {#Count}. The parameter <language> specifies custom translation language ignoring
+// configured language.
+//
+// The translation entry for <key> may be a plain string, in which case it is used directly for
+// every plural form, or a structured object keyed by CLDR plural categories ("zero", "one",
+// "two", "few", "many", "other"). If the resolved form is missing, "other" is used instead. If
+// <key> cannot be found for the resolved language, the negotiated fallback chain (see
+// MatchLanguage and Options.FallbackLanguages) is tried next, and finally the raw <key> itself
+// is used as the message.
+func (m *Manager) TranslatePlural(key string, count interface{}, args map[string]interface{}, language ...string) string {
+	m.init()
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	transLang := m.options.Language
+	if len(language) > 0 && language[0] != "" {
+		transLang = language[0]
+	}
+
+	values := make(map[string]interface{}, len(args)+1)
+	for k, v := range args {
+		values[k] = v
+	}
+	if _, ok := values["Count"]; !ok {
+		values["Count"] = count
+	}
+
+	content := key
+	for _, lang := range m.resolveLanguageChain(transLang) {
+		if raw := m.resolveRaw(lang, key); raw != nil {
+			content = extractPluralForm(raw, SelectPluralForm(lang, count))
+			break
+		}
+	}
+	return m.substitute(content, values)
+}
+
+// TranslateSelect translates <key> using the select form chosen by <selector> (e.g.
+// map[string]string{"gender": "female"}), substituting <args> into placeholders such as
+// {#Name}. The parameter <language> specifies custom translation language ignoring configured
+// language.
+//
+// The translation entry for <key> must be a structured object with a "select" map keyed by
+// selector name (e.g. "gender"), itself mapping selector values to messages, to have any effect
+// on the result. If the resolved selector value is missing, "other" is used instead; if that is
+// also missing, the entry's own "other" plural form (if any) or the raw value is used. If <key>
+// cannot be found for the resolved language, the negotiated fallback chain (see MatchLanguage
+// and Options.FallbackLanguages) is tried next, and finally the raw <key> itself is used as the
+// message.
+func (m *Manager) TranslateSelect(key string, selector map[string]string, args map[string]interface{}, language ...string) string {
+	m.init()
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	transLang := m.options.Language
+	if len(language) > 0 && language[0] != "" {
+		transLang = language[0]
+	}
+
+	content := key
+	for _, lang := range m.resolveLanguageChain(transLang) {
+		if raw := m.resolveRaw(lang, key); raw != nil {
+			content = extractSelectForm(raw, selector)
+			break
+		}
+	}
+	return m.substitute(content, args)
+}
+
+// Ts is alias of TranslateSelect for convenience.
+func (m *Manager) Ts(key string, selector map[string]string, args map[string]interface{}) string {
+	return m.TranslateSelect(key, selector, args)
+}
+
+// Tsl is alias of TranslateSelect for convenience, with an explicit language override.
+func (m *Manager) Tsl(language string, key string, selector map[string]string, args map[string]interface{}) string {
+	return m.TranslateSelect(key, selector, args, language)
+}
+
+// resolveRaw returns the raw decoded message value for <key> in <language>, or nil if the
+// language or key does not exist.
+func (m *Manager) resolveRaw(language string, key string) interface{} {
+	data := m.data[language]
 	if data == nil {
-		return content
+		return nil
 	}
-	// Parse content as name.
-	if v, ok := data[content]; ok {
+	if v, ok := data[key]; ok {
 		return v
 	}
-	// Parse content as variables container.
+	return nil
+}
+
+// extractPluralForm resolves the message text for <form> out of a raw translation value. A
+// plain string is returned as-is regardless of <form>. A structured message falls back to the
+// "other" form when <form> is not present.
+func extractPluralForm(raw interface{}, form string) string {
+	switch v := raw.(type) {
+	case string:
+		return v
+	case map[string]interface{}:
+		if s, ok := v[form]; ok {
+			return gconv.String(s)
+		}
+		if s, ok := v["other"]; ok {
+			return gconv.String(s)
+		}
+	}
+	return gconv.String(raw)
+}
+
+// extractSelectForm resolves the message text out of a raw translation value's "select" map
+// for <selector> (e.g. map[string]string{"gender": "female"}). A plain string is returned as-is.
+// A structured message falls back to its "other" selector value, then its "other" plural form,
+// then the raw value itself, in that order, when no entry in <selector> matches.
+func extractSelectForm(raw interface{}, selector map[string]string) string {
+	obj, ok := raw.(map[string]interface{})
+	if !ok {
+		return gconv.String(raw)
+	}
+	if sel, ok := obj["select"].(map[string]interface{}); ok {
+		for name, value := range selector {
+			options, ok := sel[name].(map[string]interface{})
+			if !ok {
+				continue
+			}
+			if s, ok := options[value]; ok {
+				return gconv.String(s)
+			}
+			if s, ok := options["other"]; ok {
+				return gconv.String(s)
+			}
+		}
+	}
+	if s, ok := obj["other"]; ok {
+		return gconv.String(s)
+	}
+	return gconv.String(raw)
+}
+
+// substitute replaces placeholders such as {#Name} in <content> using <args>, leaving any
+// unmatched placeholder untouched.
+func (m *Manager) substitute(content string, args map[string]interface{}) string {
 	result, _ := gregex.ReplaceStringFuncMatch(
 		m.pattern, content,
 		func(match []string) string {
-			if v, ok := data[match[1]]; ok {
-				return v
+			if v, ok := args[match[1]]; ok {
+				return gconv.String(v)
 			}
 			return match[0]
 		})
-	intlog.Printf(`Translate for language: %s`, transLang)
 	return result
 }
 
@@ -204,8 +401,12 @@ func (m *Manager) GetContent(key string, language ...string) string {
 	} else {
 		transLang = m.options.Language
 	}
-	if data, ok := m.data[transLang]; ok {
-		return data[key]
+	for _, lang := range m.resolveLanguageChain(transLang) {
+		if data, ok := m.data[lang]; ok {
+			if v, ok := data[key]; ok {
+				return gconv.String(v)
+			}
+		}
 	}
 	return ""
 }
@@ -215,7 +416,8 @@ func (m *Manager) Init() {
 }
 
 // init initializes the manager for lazy initialization design.
-// The i18n manager is only initialized once.
+// The i18n manager is only initialized once, unless nothing could be loaded yet (e.g. the
+// configured directory does not exist), in which case it is retried on the next call.
 func (m *Manager) init() {
 	m.mu.RLock()
 	// If the data is not nil, means it's already initialized.
@@ -227,125 +429,18 @@ func (m *Manager) init() {
 
 	m.mu.Lock()
 	defer m.mu.Unlock()
-	if m.options.FS != nil {
+	if m.data != nil {
+		return
+	}
 
-		m.data = make(map[string]map[string]string)
-		fs.WalkDir(m.options.FS, m.options.Path, func(fsPath string, d fs.DirEntry, err error) error {
-			if err != nil {
-				return nil
-			}
-			if d.IsDir() {
-				return nil
-			}
-			var (
-				path  string
-				name  string
-				lang  string
-				array []string
-			)
-			name = fsPath
-			path = name
-			if len(path) == 0 {
-				return nil
-			}
-			array = strings.Split(path, "/")
-			if len(array) > 1 {
-				lang = array[len(array)-2]
-			} else {
-				lang = gfile.Name(array[0])
-			}
-			if m.data[lang] == nil {
-				m.data[lang] = make(map[string]string)
-			}
-			content, err := m.options.FS.ReadFile(name)
-			if err != nil {
-				intlog.Errorf("load i18n file '%s' failed: %v", name, err)
-				return nil
-			}
-			if j, err := gjson.LoadContent(content); err == nil {
-				for k, v := range j.Map() {
-					m.data[lang][k] = gconv.String(v)
-				}
-			} else {
-				intlog.Errorf("load i18n file '%s' failed: %v", name, err)
-			}
-			return nil
-		})
-	} else if gres.Contains(m.options.Path) {
-		files := gres.ScanDirFile(m.options.Path, "*.*", true)
-		if len(files) > 0 {
-			var (
-				path  string
-				name  string
-				lang  string
-				array []string
-			)
-			m.data = make(map[string]map[string]string)
-			for _, file := range files {
-				name = file.Name()
-				path = name[len(m.options.Path)+1:]
-				array = strings.Split(path, "/")
-				if len(array) > 1 {
-					lang = array[0]
-				} else {
-					lang = gfile.Name(array[0])
-				}
-				if m.data[lang] == nil {
-					m.data[lang] = make(map[string]string)
-				}
-				if j, err := gjson.LoadContent(file.Content()); err == nil {
-					for k, v := range j.Map() {
-						m.data[lang][k] = gconv.String(v)
-					}
-				} else {
-					intlog.Errorf("load i18n file '%s' failed: %v", name, err)
-				}
-			}
-		}
-	} else if m.options.Path != "" {
-		files, _ := gfile.ScanDirFile(m.options.Path, "*.*", true)
-		if len(files) == 0 {
-			//intlog.Printf(
-			//	"no i18n files found in configured directory: %s",
-			//	m.options.Path,
-			//)
-			return
-		}
-		var (
-			path  string
-			lang  string
-			array []string
-		)
-		m.data = make(map[string]map[string]string)
-		for _, file := range files {
-			path = file[len(m.options.Path)+1:]
-			array = strings.Split(path, gfile.Separator)
-			if len(array) > 1 {
-				lang = array[0]
-			} else {
-				lang = gfile.Name(array[0])
-			}
-			if m.data[lang] == nil {
-				m.data[lang] = make(map[string]string)
-			}
-			if j, err := gjson.LoadContent(gfile.GetBytes(file)); err == nil {
-				for k, v := range j.Map() {
-					m.data[lang][k] = gconv.String(v)
-				}
-				intlog.Printf("load i18n file '%s' success, lang: '%s'", file, lang)
-			} else {
-				intlog.Errorf("load i18n file '%s' failed: %v", file, err)
-			}
-		}
-		// Monitor changes of i18n files for hot reload feature.
-		cb, errCb := gfsnotify.Add(m.options.Path, func(event *gfsnotify.Event) {
-			// Any changes of i18n files, clear the data.
-			m.mu.Lock()
-			m.data = nil
-			intlog.Printf("i18n gfsnotify event: %+v, path: '%s'\r\n", event, m.options.Path)
-			m.mu.Unlock()
-			gfsnotify.Exit()
-		})
-		intlog.Printf("i18n add gfsnotify '%v' finish, errCb: '%v'", cb, errCb)
+	m.data = make(map[string]map[string]interface{})
+	for _, src := range m.allSources() {
+		m.loadSource(src)
+	}
+	m.loadLanguagePaths()
+	if len(m.data) == 0 {
+		m.data = nil
+		return
 	}
+	m.buildMatcher()
 }
@@ -0,0 +1,68 @@
+// Copyright GoFrame Author(https://goframe.org). All Rights Reserved.
+//
+// This Source Code Form is subject to the terms of the MIT License.
+// If a copy of the MIT was not distributed with this file,
+// You can obtain one at https://github.com/gogf/gf.
+
+package gi18n
+
+import (
+	"embed"
+	"testing"
+
+	"github.com/gogf/gf/test/gtest"
+)
+
+//go:embed testdata/embedsrc
+var testRegisterSourceFS embed.FS
+
+func Test_Manager_TranslateNS(t *testing.T) {
+	gtest.C(t, func(t *gtest.T) {
+		m := new()
+		m.data = map[string]map[string]interface{}{
+			"en": {
+				"save":          "Save",
+				"billing:save":  "Save invoice",
+				"common:cancel": "Cancel",
+			},
+		}
+
+		// Default namespace is unprefixed.
+		t.Assert(m.Translate("save", "en"), "Save")
+		// "namespace:key" syntax works directly with Translate.
+		t.Assert(m.Translate("billing:save", "en"), "Save invoice")
+		// TranslateNS is equivalent, without callers having to build the composite key.
+		t.Assert(m.TranslateNS("billing", "save", "en"), "Save invoice")
+		t.Assert(m.TranslateNS("common", "cancel", "en"), "Cancel")
+		// A namespace does not leak into the default one.
+		t.Assert(m.TranslateNS("billing", "cancel", "en"), "billing:cancel")
+	})
+}
+
+func Test_Manager_RegisterSource_RebuildsMatcher(t *testing.T) {
+	gtest.C(t, func(t *gtest.T) {
+		m := new()
+		m.data = map[string]map[string]interface{}{
+			"en": {"hello": "Hello"},
+		}
+		m.buildMatcher()
+		// Before the zh-TW source is registered, BCP-47 negotiation can't possibly resolve to
+		// it yet.
+		t.AssertNE(m.MatchLanguage("zh-Hant-TW"), "zh-TW")
+
+		m.RegisterSource("", testRegisterSourceFS, "testdata/embedsrc")
+
+		t.Assert(m.data["zh-TW"]["hello"], "Hello TW")
+		// RegisterSource must rebuild the matcher itself, so the newly added language is
+		// reachable via BCP-47 negotiation immediately, not only after some unrelated reload
+		// happens to rebuild it later.
+		t.Assert(m.MatchLanguage("zh-Hant-TW"), "zh-TW")
+	})
+}
+
+func Test_nsKey(t *testing.T) {
+	gtest.C(t, func(t *gtest.T) {
+		t.Assert(nsKey("", "save"), "save")
+		t.Assert(nsKey("billing", "save"), "billing:save")
+	})
+}
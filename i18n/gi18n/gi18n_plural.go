@@ -0,0 +1,129 @@
+// Copyright GoFrame Author(https://goframe.org). All Rights Reserved.
+//
+// This Source Code Form is subject to the terms of the MIT License.
+// If a copy of the MIT was not distributed with this file,
+// You can obtain one at https://github.com/gogf/gf.
+
+package gi18n
+
+import (
+	"strconv"
+	"strings"
+
+	"github.com/gogf/gf/util/gconv"
+)
+
+// PluralOperands holds the CLDR plural operands derived from a numeric value, as defined by
+// Unicode TR35: https://www.unicode.org/reports/tr35/tr35-numbers.html#Operands.
+type PluralOperands struct {
+	N float64 // absolute value of the source number.
+	I int64   // integer digits of N.
+	V int     // number of visible fraction digits, with trailing zeros.
+	W int     // number of visible fraction digits, without trailing zeros.
+	F int64   // visible fraction digits, with trailing zeros, expressed as an integer.
+	T int64   // visible fraction digits, without trailing zeros, expressed as an integer.
+}
+
+// NewPluralOperands computes the CLDR plural operands for <count>, which may be any numeric
+// type or a string containing a (possibly decimal) number.
+func NewPluralOperands(count interface{}) PluralOperands {
+	s := strings.TrimPrefix(strings.TrimSpace(gconv.String(count)), "-")
+	if s == "" {
+		s = "0"
+	}
+	intPart, fracPart := s, ""
+	if idx := strings.IndexByte(s, '.'); idx >= 0 {
+		intPart, fracPart = s[:idx], s[idx+1:]
+	}
+	if intPart == "" {
+		intPart = "0"
+	}
+	i, _ := strconv.ParseInt(intPart, 10, 64)
+	v := len(fracPart)
+	var f int64
+	if fracPart != "" {
+		f, _ = strconv.ParseInt(fracPart, 10, 64)
+	}
+	trimmed := strings.TrimRight(fracPart, "0")
+	w := len(trimmed)
+	var t int64
+	if trimmed != "" {
+		t, _ = strconv.ParseInt(trimmed, 10, 64)
+	}
+	n, _ := strconv.ParseFloat(s, 64)
+	return PluralOperands{N: n, I: i, V: v, W: w, F: f, T: t}
+}
+
+// pluralRuleFunc computes the CLDR plural category ("zero", "one", "two", "few", "many" or
+// "other") for a given set of plural operands.
+type pluralRuleFunc func(PluralOperands) string
+
+// pluralRules holds the CLDR plural rules for the locales this package ships support for.
+// Unlisted locales fall back to pluralRuleDefault.
+var pluralRules = map[string]pluralRuleFunc{
+	"en": pluralRuleDefault,
+	"ru": pluralRuleRu,
+	"ar": pluralRuleAr,
+}
+
+// pluralRuleDefault implements the common "one vs. other" rule shared by English and most
+// other CLDR locales that only distinguish a singular and a plural form.
+func pluralRuleDefault(o PluralOperands) string {
+	if o.I == 1 && o.V == 0 {
+		return "one"
+	}
+	return "other"
+}
+
+// pluralRuleRu implements the CLDR plural rule for Russian.
+func pluralRuleRu(o PluralOperands) string {
+	mod10 := o.I % 10
+	mod100 := o.I % 100
+	switch {
+	case o.V == 0 && mod10 == 1 && mod100 != 11:
+		return "one"
+	case o.V == 0 && mod10 >= 2 && mod10 <= 4 && (mod100 < 12 || mod100 > 14):
+		return "few"
+	case o.V == 0 && (mod10 == 0 || (mod10 >= 5 && mod10 <= 9) || (mod100 >= 11 && mod100 <= 14)):
+		return "many"
+	default:
+		return "other"
+	}
+}
+
+// pluralRuleAr implements the CLDR plural rule for Arabic.
+func pluralRuleAr(o PluralOperands) string {
+	mod100 := int64(o.N) % 100
+	// The few/many ranges only match integral values of n, e.g. 3.0 but not 3.5.
+	isInt := o.N == float64(int64(o.N))
+	switch {
+	case o.N == 0:
+		return "zero"
+	case o.N == 1:
+		return "one"
+	case o.N == 2:
+		return "two"
+	case isInt && mod100 >= 3 && mod100 <= 10:
+		return "few"
+	case isInt && mod100 >= 11 && mod100 <= 99:
+		return "many"
+	default:
+		return "other"
+	}
+}
+
+// SelectPluralForm returns the CLDR plural category ("zero", "one", "two", "few", "many" or
+// "other") for <count> under the plural rules of <language>. The region/script subtags of
+// <language> are ignored; locales this package does not ship a rule table for use the common
+// "one vs. other" rule as a reasonable default.
+func SelectPluralForm(language string, count interface{}) string {
+	base := language
+	if idx := strings.IndexByte(base, '-'); idx >= 0 {
+		base = base[:idx]
+	}
+	rule, ok := pluralRules[base]
+	if !ok {
+		rule = pluralRuleDefault
+	}
+	return rule(NewPluralOperands(count))
+}
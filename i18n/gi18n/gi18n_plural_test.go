@@ -0,0 +1,131 @@
+// Copyright GoFrame Author(https://goframe.org). All Rights Reserved.
+//
+// This Source Code Form is subject to the terms of the MIT License.
+// If a copy of the MIT was not distributed with this file,
+// You can obtain one at https://github.com/gogf/gf.
+
+package gi18n
+
+import (
+	"testing"
+
+	"github.com/gogf/gf/test/gtest"
+)
+
+func Test_SelectPluralForm(t *testing.T) {
+	gtest.C(t, func(t *gtest.T) {
+		// English only distinguishes singular from plural.
+		t.Assert(SelectPluralForm("en", 1), "one")
+		t.Assert(SelectPluralForm("en", 0), "other")
+		t.Assert(SelectPluralForm("en", 2), "other")
+		t.Assert(SelectPluralForm("en", 21), "other")
+	})
+	gtest.C(t, func(t *gtest.T) {
+		// Russian distinguishes one/few/many/other based on the last one or two digits.
+		t.Assert(SelectPluralForm("ru", 1), "one")
+		t.Assert(SelectPluralForm("ru", 21), "one")
+		t.Assert(SelectPluralForm("ru", 2), "few")
+		t.Assert(SelectPluralForm("ru", 3), "few")
+		t.Assert(SelectPluralForm("ru", 4), "few")
+		t.Assert(SelectPluralForm("ru", 5), "many")
+		t.Assert(SelectPluralForm("ru", 11), "many")
+		t.Assert(SelectPluralForm("ru", 0), "many")
+		t.Assert(SelectPluralForm("ru", 1.5), "other")
+	})
+	gtest.C(t, func(t *gtest.T) {
+		// Arabic has the full six-form CLDR rule set.
+		t.Assert(SelectPluralForm("ar", 0), "zero")
+		t.Assert(SelectPluralForm("ar", 1), "one")
+		t.Assert(SelectPluralForm("ar", 2), "two")
+		t.Assert(SelectPluralForm("ar", 3), "few")
+		t.Assert(SelectPluralForm("ar", 10), "few")
+		t.Assert(SelectPluralForm("ar", 11), "many")
+		t.Assert(SelectPluralForm("ar", 99), "many")
+		t.Assert(SelectPluralForm("ar", 100), "other")
+	})
+}
+
+func Test_Manager_TranslatePlural(t *testing.T) {
+	gtest.C(t, func(t *gtest.T) {
+		m := new()
+		m.data = map[string]map[string]interface{}{
+			"en": {
+				"apple_count": map[string]interface{}{
+					"one":   "You have {#Count} apple",
+					"other": "You have {#Count} apples",
+				},
+			},
+			"ru": {
+				"apple_count": map[string]interface{}{
+					"one":   "У вас {#Count} яблоко",
+					"few":   "У вас {#Count} яблока",
+					"many":  "У вас {#Count} яблок",
+					"other": "У вас {#Count} яблока",
+				},
+			},
+			"ar": {
+				"apple_count": map[string]interface{}{
+					"zero":  "ليس لديك تفاح",
+					"one":   "لديك تفاحة واحدة",
+					"two":   "لديك تفاحتان",
+					"few":   "لديك {#Count} تفاحات",
+					"many":  "لديك {#Count} تفاحة",
+					"other": "لديك {#Count} تفاحة",
+				},
+			},
+		}
+
+		t.Assert(m.TranslatePlural("apple_count", 1, nil, "en"), "You have 1 apple")
+		t.Assert(m.TranslatePlural("apple_count", 2, nil, "en"), "You have 2 apples")
+
+		t.Assert(m.TranslatePlural("apple_count", 1, nil, "ru"), "У вас 1 яблоко")
+		t.Assert(m.TranslatePlural("apple_count", 3, nil, "ru"), "У вас 3 яблока")
+		t.Assert(m.TranslatePlural("apple_count", 5, nil, "ru"), "У вас 5 яблок")
+
+		t.Assert(m.TranslatePlural("apple_count", 0, nil, "ar"), "ليس لديك تفاح")
+		t.Assert(m.TranslatePlural("apple_count", 2, nil, "ar"), "لديك تفاحتان")
+		t.Assert(m.TranslatePlural("apple_count", 7, nil, "ar"), "لديك 7 تفاحات")
+
+		// Missing key falls back to the raw key.
+		t.Assert(m.TranslatePlural("no_such_key", 1, nil, "en"), "no_such_key")
+	})
+}
+
+func Test_Manager_TranslateSelect(t *testing.T) {
+	gtest.C(t, func(t *gtest.T) {
+		m := new()
+		m.data = map[string]map[string]interface{}{
+			"en": {
+				"invited_you": map[string]interface{}{
+					"select": map[string]interface{}{
+						"gender": map[string]interface{}{
+							"male":   "He invited {#Name}",
+							"female": "She invited {#Name}",
+							"other":  "They invited {#Name}",
+						},
+					},
+				},
+			},
+		}
+
+		args := map[string]interface{}{"Name": "Alex"}
+		t.Assert(m.TranslateSelect("invited_you", map[string]string{"gender": "male"}, args, "en"), "He invited Alex")
+		t.Assert(m.TranslateSelect("invited_you", map[string]string{"gender": "female"}, args, "en"), "She invited Alex")
+		// An unmapped selector value falls back to "other".
+		t.Assert(m.TranslateSelect("invited_you", map[string]string{"gender": "nonbinary"}, args, "en"), "They invited Alex")
+
+		// A plain string entry is used as-is regardless of the selector.
+		m.data["en"]["hello"] = "Hello {#Name}"
+		t.Assert(m.TranslateSelect("hello", map[string]string{"gender": "male"}, args, "en"), "Hello Alex")
+
+		// A structured entry with no "select" map falls back to its "other" plural form.
+		m.data["en"]["apple_count"] = map[string]interface{}{
+			"one":   "You have {#Count} apple",
+			"other": "You have {#Count} apples",
+		}
+		t.Assert(m.TranslateSelect("apple_count", map[string]string{"gender": "male"}, args, "en"), "You have {#Count} apples")
+
+		// Missing key falls back to the raw key.
+		t.Assert(m.TranslateSelect("no_such_key", map[string]string{"gender": "male"}, args, "en"), "no_such_key")
+	})
+}
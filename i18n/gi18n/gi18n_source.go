@@ -0,0 +1,150 @@
+// Copyright GoFrame Author(https://goframe.org). All Rights Reserved.
+//
+// This Source Code Form is subject to the terms of the MIT License.
+// If a copy of the MIT was not distributed with this file,
+// You can obtain one at https://github.com/gogf/gf.
+
+package gi18n
+
+import (
+	"embed"
+	"io/fs"
+	"strings"
+
+	"github.com/gogf/gf/internal/intlog"
+	"github.com/gogf/gf/os/gfile"
+	"github.com/gogf/gf/os/gres"
+)
+
+// source describes one independent origin of translation messages merged into a Manager:
+// either Options.Path/Options.FS itself, one of Options.Namespaces, or a source registered at
+// runtime via Manager.RegisterSource. Each source is loaded (and, for plain filesystem paths,
+// watched) independently, so that adding one source never disturbs another.
+type source struct {
+	ns   string    // namespace this source merges into ("" is the default, unprefixed namespace).
+	path string    // directory path, either on the filesystem, under gres, or the root inside fs.
+	fs   *embed.FS // non-nil for an embed.FS-backed source.
+}
+
+// nsKey returns the composite data-map key for <key> within namespace <ns>. The default
+// namespace ("") stores keys unprefixed, preserving compatibility with flat translation files.
+func nsKey(ns string, key string) string {
+	if ns == "" {
+		return key
+	}
+	return ns + ":" + key
+}
+
+// allSources returns every source to load: the primary Options.Path/Options.FS source, one
+// source per entry of Options.Namespaces, and any sources registered via RegisterSource.
+func (m *Manager) allSources() []source {
+	srcs := make([]source, 0, 1+len(m.options.Namespaces)+len(m.sources))
+	srcs = append(srcs, source{path: m.options.Path, fs: m.options.FS})
+	for ns, path := range m.options.Namespaces {
+		srcs = append(srcs, source{ns: ns, path: path})
+	}
+	srcs = append(srcs, m.sources...)
+	return srcs
+}
+
+// RegisterSource registers an additional embed.FS-backed translation source for namespace
+// <ns>, merged into the Manager's data under keys of the form "ns:key". This lets large
+// applications ship translations per module (e.g. billing, admin, emails), each with its own
+// embed.FS, without stomping each other's keys or forcing one giant JSON file.
+func (m *Manager) RegisterSource(ns string, fsys embed.FS, path string) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	src := source{ns: ns, path: path, fs: &fsys}
+	m.sources = append(m.sources, src)
+	if m.data != nil {
+		m.loadSource(src)
+		m.buildMatcher()
+	}
+}
+
+// loadSource dispatches <src> to the loader matching its kind: embed.FS, gres, or plain
+// filesystem. It must be called with m.mu held for writing.
+func (m *Manager) loadSource(src source) {
+	switch {
+	case src.fs != nil:
+		m.loadFSSource(src)
+	case src.path == "":
+		return
+	case gres.Contains(src.path):
+		m.loadResourceSource(src)
+	default:
+		m.loadFileSource(src)
+	}
+}
+
+// loadFSSource loads every file under an embed.FS source. Hot reload is a no-op for embed.FS,
+// as its content is baked in at compile time.
+func (m *Manager) loadFSSource(src source) {
+	fs.WalkDir(*src.fs, src.path, func(fsPath string, d fs.DirEntry, err error) error {
+		if err != nil || d.IsDir() || fsPath == "" {
+			return nil
+		}
+		lang := languageFromPath(fsPath, "/", true)
+		content, err := src.fs.ReadFile(fsPath)
+		if err != nil {
+			intlog.Errorf("load i18n file '%s' failed: %v", fsPath, err)
+			return nil
+		}
+		m.loadMessages(src.ns, lang, fsPath, content)
+		return nil
+	})
+}
+
+// loadResourceSource loads every file under a gres-packed source. Hot reload is a no-op for
+// gres, as its content is baked in at compile time.
+func (m *Manager) loadResourceSource(src source) {
+	for _, file := range gres.ScanDirFile(src.path, "*.*", true) {
+		name := file.Name()
+		lang := languageFromPath(name[len(src.path)+1:], "/", false)
+		m.loadMessages(src.ns, lang, name, file.Content())
+	}
+}
+
+// loadFileSource loads every file under a plain filesystem source and, if any were found,
+// starts watching the directory for hot reload.
+func (m *Manager) loadFileSource(src source) {
+	files, _ := gfile.ScanDirFile(src.path, "*.*", true)
+	for _, file := range files {
+		lang := languageFromPath(file[len(src.path)+1:], gfile.Separator, false)
+		m.loadMessages(src.ns, lang, file, gfile.GetBytes(file))
+	}
+	if len(files) == 0 {
+		return
+	}
+	m.watchPath(src.ns, src.path, gfile.Separator, false)
+}
+
+// loadLanguagePaths loads Options.LanguagePaths, the per-language content directory overrides
+// for the default namespace.
+func (m *Manager) loadLanguagePaths() {
+	for lang, dir := range m.options.LanguagePaths {
+		files, _ := gfile.ScanDirFile(dir, "*.*", true)
+		for _, file := range files {
+			m.loadMessages("", lang, file, gfile.GetBytes(file))
+		}
+		if len(files) == 0 {
+			continue
+		}
+		m.watchFixedLangPath("", lang, dir)
+	}
+}
+
+// languageFromPath derives the language directory name out of <relPath>, split on <sep>. When
+// <parentOnly> is true (embed.FS sources), the segment immediately containing the file is
+// used; otherwise (gres and plain filesystem sources) the top-level segment is used. Either
+// way, a path with no directory segments falls back to its base name (e.g. top-level "en.json").
+func languageFromPath(relPath string, sep string, parentOnly bool) string {
+	array := strings.Split(relPath, sep)
+	if len(array) > 1 {
+		if parentOnly {
+			return array[len(array)-2]
+		}
+		return array[0]
+	}
+	return gfile.Name(array[0])
+}
@@ -0,0 +1,116 @@
+// Copyright GoFrame Author(https://goframe.org). All Rights Reserved.
+//
+// This Source Code Form is subject to the terms of the MIT License.
+// If a copy of the MIT was not distributed with this file,
+// You can obtain one at https://github.com/gogf/gf.
+
+package gi18n
+
+import (
+	"strings"
+	"time"
+
+	"github.com/gogf/gf/internal/intlog"
+	"github.com/gogf/gf/os/gfile"
+	"github.com/gogf/gf/os/gfsnotify"
+)
+
+// hotReloadDebounce is the coalescing window used to merge a burst of filesystem events (e.g.
+// a git checkout touching a whole translation directory at once) into a single reload pass.
+const hotReloadDebounce = 100 * time.Millisecond
+
+// reloadTask describes one file that needs to be re-read into the manager's data, as scheduled
+// by a filesystem watch callback.
+type reloadTask struct {
+	ns       string
+	lang     string
+	filePath string
+}
+
+// OnReload registers <fn> to be called with the affected language every time a hot-reloadable
+// source changes on disk and has been merged into the manager's data. It has no effect for
+// embed.FS or gres sources, which are never watched, nor when Options.DisableHotReload is set.
+func (m *Manager) OnReload(fn func(lang string)) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.reloadFuncs = append(m.reloadFuncs, fn)
+}
+
+// watchPath starts watching <path> for changes, deriving the language of the changed file from
+// its path relative to <path> (see languageFromPath), and scheduling a debounced reload of that
+// single file under namespace <ns>. It is a no-op if Options.DisableHotReload is set.
+func (m *Manager) watchPath(ns string, path string, sep string, parentOnly bool) {
+	if m.options.DisableHotReload {
+		return
+	}
+	cb, errCb := gfsnotify.Add(path, func(event *gfsnotify.Event) {
+		rel := strings.TrimPrefix(event.Path, path+sep)
+		lang := languageFromPath(rel, sep, parentOnly)
+		m.scheduleReload(ns, lang, event.Path)
+	})
+	intlog.Printf("i18n add gfsnotify '%v' finish, errCb: '%v'", cb, errCb)
+}
+
+// watchFixedLangPath starts watching <path> for changes, reloading any changed file under the
+// fixed <lang>/<ns> regardless of where in <path> it lives. It is used for
+// Options.LanguagePaths, where the whole directory belongs to one configured language rather
+// than one per subdirectory. It is a no-op if Options.DisableHotReload is set.
+func (m *Manager) watchFixedLangPath(ns string, lang string, path string) {
+	if m.options.DisableHotReload {
+		return
+	}
+	cb, errCb := gfsnotify.Add(path, func(event *gfsnotify.Event) {
+		m.scheduleReload(ns, lang, event.Path)
+	})
+	intlog.Printf("i18n add gfsnotify '%v' finish, errCb: '%v'", cb, errCb)
+}
+
+// scheduleReload records a pending reload for <filePath> and (re)starts the debounce timer.
+// Multiple events for the same file within the debounce window collapse into one reload.
+func (m *Manager) scheduleReload(ns string, lang string, filePath string) {
+	m.watchMu.Lock()
+	defer m.watchMu.Unlock()
+	if m.pendingReloads == nil {
+		m.pendingReloads = make(map[string]reloadTask)
+	}
+	m.pendingReloads[filePath] = reloadTask{ns: ns, lang: lang, filePath: filePath}
+	if m.reloadTimer != nil {
+		m.reloadTimer.Stop()
+	}
+	m.reloadTimer = time.AfterFunc(hotReloadDebounce, m.flushReloads)
+}
+
+// flushReloads re-reads every file accumulated by scheduleReload into the manager's data under
+// the write lock, rebuilds the language matcher, and notifies OnReload callbacks for every
+// distinct language touched.
+func (m *Manager) flushReloads() {
+	m.watchMu.Lock()
+	tasks := m.pendingReloads
+	m.pendingReloads = nil
+	m.reloadTimer = nil
+	m.watchMu.Unlock()
+	if len(tasks) == 0 {
+		return
+	}
+
+	m.mu.Lock()
+	changed := make(map[string]bool, len(tasks))
+	for _, task := range tasks {
+		content := gfile.GetBytes(task.filePath)
+		if len(content) == 0 {
+			intlog.Printf("i18n hot reload: '%s' is empty or was removed, skipping", task.filePath)
+			continue
+		}
+		m.loadMessages(task.ns, task.lang, task.filePath, content)
+		changed[task.lang] = true
+	}
+	m.buildMatcher()
+	callbacks := append([]func(string){}, m.reloadFuncs...)
+	m.mu.Unlock()
+
+	for lang := range changed {
+		for _, cb := range callbacks {
+			cb(lang)
+		}
+	}
+}
@@ -0,0 +1,91 @@
+// Copyright GoFrame Author(https://goframe.org). All Rights Reserved.
+//
+// This Source Code Form is subject to the terms of the MIT License.
+// If a copy of the MIT was not distributed with this file,
+// You can obtain one at https://github.com/gogf/gf.
+
+package gi18n
+
+import (
+	"testing"
+	"time"
+
+	"github.com/gogf/gf/os/gfile"
+	"github.com/gogf/gf/test/gtest"
+)
+
+func Test_Manager_ScheduleReload_Debounce(t *testing.T) {
+	gtest.C(t, func(t *gtest.T) {
+		dir := gfile.TempDir("gi18n-watch-test")
+		file := dir + gfile.Separator + "en.json"
+		_ = gfile.Mkdir(dir)
+		defer gfile.Remove(dir)
+
+		_ = gfile.PutContents(file, `{"hello":"Hello"}`)
+
+		m := new()
+		m.data = make(map[string]map[string]interface{})
+
+		var reloaded []string
+		m.OnReload(func(lang string) {
+			reloaded = append(reloaded, lang)
+		})
+
+		// A burst of events for the same file should coalesce into a single reload.
+		m.scheduleReload("", "en", file)
+		m.scheduleReload("", "en", file)
+		m.scheduleReload("", "en", file)
+
+		time.Sleep(2 * hotReloadDebounce)
+
+		t.Assert(m.data["en"]["hello"], "Hello")
+		t.Assert(len(reloaded), 1)
+		t.Assert(reloaded[0], "en")
+	})
+}
+
+func Test_Manager_Reload_RemovesDroppedKeys(t *testing.T) {
+	gtest.C(t, func(t *gtest.T) {
+		dir := gfile.TempDir("gi18n-watch-test-drop")
+		file := dir + gfile.Separator + "en.json"
+		_ = gfile.Mkdir(dir)
+		defer gfile.Remove(dir)
+
+		_ = gfile.PutContents(file, `{"hello":"Hello","bye":"Bye"}`)
+
+		m := new()
+		m.data = make(map[string]map[string]interface{})
+		m.loadMessages("", "en", file, gfile.GetBytes(file))
+		t.Assert(m.data["en"]["hello"], "Hello")
+		t.Assert(m.data["en"]["bye"], "Bye")
+
+		// Rewriting the file to drop "bye" and reloading it should remove the stale key, not
+		// leave it behind forever.
+		_ = gfile.PutContents(file, `{"hello":"Hello there"}`)
+		m.scheduleReload("", "en", file)
+		time.Sleep(2 * hotReloadDebounce)
+
+		t.Assert(m.data["en"]["hello"], "Hello there")
+		_, ok := m.data["en"]["bye"]
+		t.Assert(ok, false)
+	})
+}
+
+func Test_Manager_FlushReloads_MissingFile(t *testing.T) {
+	gtest.C(t, func(t *gtest.T) {
+		m := new()
+		m.data = map[string]map[string]interface{}{
+			"en": {"hello": "Hello"},
+		}
+
+		called := false
+		m.OnReload(func(lang string) { called = true })
+
+		// A file that no longer exists (e.g. removed) is skipped, not merged as empty content.
+		m.scheduleReload("", "en", "/no/such/file.json")
+		time.Sleep(2 * hotReloadDebounce)
+
+		t.Assert(m.data["en"]["hello"], "Hello")
+		t.Assert(called, false)
+	})
+}